@@ -0,0 +1,310 @@
+package goes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrBulkIndexerClosed is returned by Add once the BulkIndexer has been
+// closed and is no longer accepting documents.
+var ErrBulkIndexerClosed = errors.New("goes: bulk indexer is closed")
+
+// BulkIndexerStats holds aggregate counters for a BulkIndexer's lifetime.
+// A snapshot is obtained with BulkIndexer.Stats.
+type BulkIndexerStats struct {
+	Indexed uint64
+	Failed  uint64
+	Bytes   uint64
+	// Latency is the cumulative time spent inside BulkSend calls, so
+	// callers can derive an average by dividing by Indexed+Failed.
+	Latency time.Duration
+}
+
+// BulkIndexerConfig configures a BulkIndexer's batching and concurrency.
+type BulkIndexerConfig struct {
+	// FlushBytes flushes the current batch once its estimated JSON size
+	// reaches this many bytes. 0 disables the check.
+	FlushBytes int
+	// FlushDocs flushes the current batch once it holds this many
+	// documents. 0 disables the check.
+	FlushDocs int
+	// FlushInterval flushes the current batch on a timer even if
+	// neither threshold above is hit, so documents don't sit
+	// unindexed indefinitely. Defaults to one second.
+	FlushInterval time.Duration
+	// Workers is the number of goroutines batching and sending
+	// documents concurrently. Defaults to 1.
+	Workers int
+	// OnItem, if set, is called once per document after its batch has
+	// been sent, with err set if that specific item failed.
+	OnItem func(doc Document, err error)
+	// OnFlush, if set, is called once per batch after it has been
+	// sent, with the batch size and the error for the request as a
+	// whole (per-item errors are reported separately via OnItem).
+	OnFlush func(n int, err error)
+	// Context bounds every bulk request the workers send. Defaults to
+	// context.Background().
+	Context context.Context
+}
+
+// BulkIndexer batches documents pushed via Add and sends them through
+// BulkSend from a pool of worker goroutines.
+type BulkIndexer struct {
+	client *Client
+	config BulkIndexerConfig
+
+	docs chan Document
+	done chan struct{}
+	wg   sync.WaitGroup
+
+	closeMu sync.Mutex
+	closed  bool
+
+	statsMu sync.Mutex
+	stats   BulkIndexerStats
+}
+
+// NewBulkIndexer creates a BulkIndexer bound to c and starts its worker
+// pool. Call Close to flush any buffered documents and stop the workers.
+func NewBulkIndexer(c *Client, config BulkIndexerConfig) *BulkIndexer {
+	if config.Workers <= 0 {
+		config.Workers = 1
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = time.Second
+	}
+	if config.Context == nil {
+		config.Context = context.Background()
+	}
+
+	bi := &BulkIndexer{
+		client: c,
+		config: config,
+		docs:   make(chan Document),
+		done:   make(chan struct{}),
+	}
+
+	for i := 0; i < config.Workers; i++ {
+		bi.wg.Add(1)
+		go bi.worker()
+	}
+
+	return bi
+}
+
+// Add enqueues a document to be indexed. It blocks until a worker accepts
+// the document into its current batch, ctx is done, or the indexer is
+// closed.
+func (bi *BulkIndexer) Add(ctx context.Context, doc Document) error {
+	bi.closeMu.Lock()
+	closed := bi.closed
+	bi.closeMu.Unlock()
+	if closed {
+		return ErrBulkIndexerClosed
+	}
+
+	select {
+	case bi.docs <- doc:
+		return nil
+	case <-bi.done:
+		return ErrBulkIndexerClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stats returns a snapshot of the indexer's aggregate counters.
+func (bi *BulkIndexer) Stats() BulkIndexerStats {
+	bi.statsMu.Lock()
+	defer bi.statsMu.Unlock()
+	return bi.stats
+}
+
+// Close stops accepting new documents, flushes any buffered ones, and
+// waits for all workers to finish. It returns ctx.Err() if ctx is done
+// before the workers drain, so a hung node can't make Close block
+// forever; the workers keep running in the background regardless.
+func (bi *BulkIndexer) Close(ctx context.Context) error {
+	bi.closeMu.Lock()
+	if bi.closed {
+		bi.closeMu.Unlock()
+		return nil
+	}
+	bi.closed = true
+	bi.closeMu.Unlock()
+
+	close(bi.done)
+
+	drained := make(chan struct{})
+	go func() {
+		bi.wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// worker owns a reusable batch buffer, a parallel slice of each
+// document's already-computed size, and the [][]byte buffer send
+// encodes batches into, reusing all three flush to flush.
+func (bi *BulkIndexer) worker() {
+	defer bi.wg.Done()
+
+	batch := make([]Document, 0, bi.batchHint())
+	sizes := make([]int, 0, bi.batchHint())
+	batchBytes := 0
+	var bulkBuf [][]byte
+
+	timer := time.NewTimer(bi.config.FlushInterval)
+	defer timer.Stop()
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		bi.send(batch, sizes, &bulkBuf)
+		batch = batch[:0]
+		sizes = sizes[:0]
+		batchBytes = 0
+	}
+
+	for {
+		select {
+		case doc := <-bi.docs:
+			size := estimatedSize(doc)
+			batch = append(batch, doc)
+			sizes = append(sizes, size)
+			batchBytes += size
+
+			if bi.shouldFlush(len(batch), batchBytes) {
+				flush()
+				resetTimer(timer, bi.config.FlushInterval)
+			}
+
+		case <-bi.done:
+			flush()
+			return
+
+		case <-timer.C:
+			flush()
+			resetTimer(timer, bi.config.FlushInterval)
+		}
+	}
+}
+
+func (bi *BulkIndexer) batchHint() int {
+	if bi.config.FlushDocs > 0 {
+		return bi.config.FlushDocs
+	}
+	return 64
+}
+
+func (bi *BulkIndexer) shouldFlush(docs, bytes int) bool {
+	if bi.config.FlushDocs > 0 && docs >= bi.config.FlushDocs {
+		return true
+	}
+	if bi.config.FlushBytes > 0 && bytes >= bi.config.FlushBytes {
+		return true
+	}
+	return false
+}
+
+// send ships a batch through BulkSendContext, records aggregate stats,
+// and invokes the configured callbacks. sizes holds each document's size
+// as already computed at intake.
+func (bi *BulkIndexer) send(batch []Document, sizes []int, bulkBuf *[][]byte) {
+	start := time.Now()
+	resp, err := bi.client.bulkSendContextBuf(bi.config.Context, batch, bulkBuf)
+	latency := time.Since(start)
+
+	itemErrs := bulkItemErrors(resp, len(batch))
+
+	// bulkSendContextBuf also returns an error when only some items
+	// failed; those are reported per item, so only an error with no
+	// per-item cause counts against every document in the batch.
+	reqErr := err
+	for _, e := range itemErrs {
+		if e != nil {
+			reqErr = nil
+			break
+		}
+	}
+
+	if bi.config.OnFlush != nil {
+		bi.config.OnFlush(len(batch), reqErr)
+	}
+
+	bi.statsMu.Lock()
+	bi.stats.Latency += latency
+	for i, doc := range batch {
+		itemErr := itemErrs[i]
+		if itemErr == nil {
+			itemErr = reqErr
+		}
+
+		if itemErr != nil {
+			bi.stats.Failed++
+		} else {
+			bi.stats.Indexed++
+		}
+		bi.stats.Bytes += uint64(sizes[i])
+
+		if bi.config.OnItem != nil {
+			bi.config.OnItem(doc, itemErr)
+		}
+	}
+	bi.statsMu.Unlock()
+}
+
+// bulkItemErrors maps a bulk *Response's per-item errors back onto the
+// positions of the documents that produced them. The result always has
+// length n so callers can index it without bounds checks.
+func bulkItemErrors(resp *Response, n int) []error {
+	errs := make([]error, n)
+	if resp == nil {
+		return errs
+	}
+
+	for idx, item := range resp.Items {
+		if idx >= n {
+			break
+		}
+		for _, i := range item {
+			if i.Error != "" {
+				errs[idx] = &SearchError{i.Error, i.Status}
+			}
+		}
+	}
+
+	return errs
+}
+
+// estimatedSize approximates the bulk payload size contributed by doc,
+// used only to decide when to flush a batch on FlushBytes.
+func estimatedSize(doc Document) int {
+	size := len(doc.BulkCommand) + 64 // action line + field names overhead
+	if doc.Fields != nil {
+		if b, err := json.Marshal(doc.Fields); err == nil {
+			size += len(b)
+		}
+	}
+	return size
+}
+
+func resetTimer(t *time.Timer, d time.Duration) {
+	if !t.Stop() {
+		select {
+		case <-t.C:
+		default:
+		}
+	}
+	t.Reset(d)
+}
@@ -0,0 +1,66 @@
+package goes
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// TestBulkIndexerAddRacesClose exercises the race the closed bool guards
+// against: without it, Add's select raced a send on bi.docs against a
+// close signal and could panic instead of returning ErrBulkIndexerClosed
+// or nil. Add and Close run concurrently here, not sequentially, so the
+// race actually happens under -race instead of just testing add-after-close.
+func TestBulkIndexerAddRacesClose(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("SplitHostPort: %v", err)
+	}
+
+	c := &Client{Host: host, Port: port, Client: http.DefaultClient}
+	bi := NewBulkIndexer(c, BulkIndexerConfig{Workers: 4})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			doc := Document{BulkCommand: BulkCommandIndex, Index: "i", Type: "t", ID: "1"}
+			if err := bi.Add(context.Background(), doc); err != nil && err != ErrBulkIndexerClosed {
+				t.Errorf("Add = %v, want nil or ErrBulkIndexerClosed", err)
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := bi.Close(context.Background()); err != nil {
+			t.Errorf("Close: %v", err)
+		}
+	}()
+
+	wg.Wait()
+}
+
+// TestBulkIndexerCloseIdempotent checks that a second Close doesn't panic
+// on an already-closed done channel.
+func TestBulkIndexerCloseIdempotent(t *testing.T) {
+	bi := NewBulkIndexer(&Client{}, BulkIndexerConfig{})
+
+	if err := bi.Close(context.Background()); err != nil {
+		t.Fatalf("first Close: %v", err)
+	}
+	if err := bi.Close(context.Background()); err != nil {
+		t.Fatalf("second Close: %v", err)
+	}
+}
@@ -7,6 +7,7 @@ package goes
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -16,6 +17,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -29,16 +31,29 @@ func (err *SearchError) Error() string {
 	return fmt.Sprintf("[%d] %s", err.StatusCode, err.Msg)
 }
 
+// Client is an elasticsearch client bound to a single host, or to a pool
+// of nodes when created with NewClientFromURLs.
+type Client struct {
+	Host    string
+	Port    string
+	IsHTTPS bool
+	Client  *http.Client
+
+	retryPolicy *RetryPolicy
+	signer      RequestSigner
+	transport   *transport
+}
+
 // NewClient initiates a new client for an elasticsearch server
 //
 // This function is pretty useless for now but might be useful in a near future
 // if wee need more features like connection pooling or load balancing.
 func NewClient(host string, port string) *Client {
-	return &Client{host, port, false, http.DefaultClient}
+	return &Client{Host: host, Port: port, IsHTTPS: false, Client: http.DefaultClient}
 }
 
 func NewHTTPSClient(host string, port string) *Client {
-	return &Client{host, port, true, http.DefaultClient}
+	return &Client{Host: host, Port: port, IsHTTPS: true, Client: http.DefaultClient}
 }
 
 // WithHTTPClient sets the http.Client to be used with the connection. Returns the original client.
@@ -49,39 +64,59 @@ func (c *Client) WithHTTPClient(cl *http.Client) *Client {
 
 // CreateIndex creates a new index represented by a name and a mapping
 func (c *Client) CreateIndex(name string, mapping interface{}) (*Response, error) {
+	return c.CreateIndexContext(context.Background(), name, mapping)
+}
+
+// CreateIndexContext is the context-aware version of CreateIndex.
+func (c *Client) CreateIndexContext(ctx context.Context, name string, mapping interface{}) (*Response, error) {
 	r := Request{
 		Query:     mapping,
 		IndexList: []string{name},
 		Method:    "PUT",
 	}
 
-	return c.Do(&r)
+	return c.DoContext(ctx, &r)
 }
 
 // DeleteIndex deletes an index represented by a name
 func (c *Client) DeleteIndex(name string) (*Response, error) {
+	return c.DeleteIndexContext(context.Background(), name)
+}
+
+// DeleteIndexContext is the context-aware version of DeleteIndex.
+func (c *Client) DeleteIndexContext(ctx context.Context, name string) (*Response, error) {
 	r := Request{
 		IndexList: []string{name},
 		Method:    "DELETE",
 	}
 
-	return c.Do(&r)
+	return c.DoContext(ctx, &r)
 }
 
 // RefreshIndex refreshes an index represented by a name
 func (c *Client) RefreshIndex(name string) (*Response, error) {
+	return c.RefreshIndexContext(context.Background(), name)
+}
+
+// RefreshIndexContext is the context-aware version of RefreshIndex.
+func (c *Client) RefreshIndexContext(ctx context.Context, name string) (*Response, error) {
 	r := Request{
 		IndexList: []string{name},
 		Method:    "POST",
 		API:       "_refresh",
 	}
 
-	return c.Do(&r)
+	return c.DoContext(ctx, &r)
 }
 
 // UpdateIndexSettings updates settings for existing index represented by a name and a settings
 // as described here: https://www.elastic.co/guide/en/elasticsearch/reference/current/indices-update-settings.html
 func (c *Client) UpdateIndexSettings(name string, settings interface{}) (*Response, error) {
+	return c.UpdateIndexSettingsContext(context.Background(), name, settings)
+}
+
+// UpdateIndexSettingsContext is the context-aware version of UpdateIndexSettings.
+func (c *Client) UpdateIndexSettingsContext(ctx context.Context, name string, settings interface{}) (*Response, error) {
 	r := Request{
 		Query:     settings,
 		IndexList: []string{name},
@@ -89,12 +124,17 @@ func (c *Client) UpdateIndexSettings(name string, settings interface{}) (*Respon
 		API:       "_settings",
 	}
 
-	return c.Do(&r)
+	return c.DoContext(ctx, &r)
 }
 
 // Optimize an index represented by a name, extra args are also allowed please check:
 // http://www.elasticsearch.org/guide/en/elasticsearch/reference/current/indices-optimize.html#indices-optimize
 func (c *Client) Optimize(indexList []string, extraArgs url.Values) (*Response, error) {
+	return c.OptimizeContext(context.Background(), indexList, extraArgs)
+}
+
+// OptimizeContext is the context-aware version of Optimize.
+func (c *Client) OptimizeContext(ctx context.Context, indexList []string, extraArgs url.Values) (*Response, error) {
 	r := Request{
 		IndexList: indexList,
 		ExtraArgs: extraArgs,
@@ -102,11 +142,16 @@ func (c *Client) Optimize(indexList []string, extraArgs url.Values) (*Response,
 		API:       "_optimize",
 	}
 
-	return c.Do(&r)
+	return c.DoContext(ctx, &r)
 }
 
 // Stats fetches statistics (_stats) for the current elasticsearch server
 func (c *Client) Stats(indexList []string, extraArgs url.Values) (*Response, error) {
+	return c.StatsContext(context.Background(), indexList, extraArgs)
+}
+
+// StatsContext is the context-aware version of Stats.
+func (c *Client) StatsContext(ctx context.Context, indexList []string, extraArgs url.Values) (*Response, error) {
 	r := Request{
 		IndexList: indexList,
 		ExtraArgs: extraArgs,
@@ -114,23 +159,75 @@ func (c *Client) Stats(indexList []string, extraArgs url.Values) (*Response, err
 		API:       "_stats",
 	}
 
-	return c.Do(&r)
+	return c.DoContext(ctx, &r)
 }
 
 // IndexStatus fetches the status (_status) for the indices defined in
 // indexList. Use _all in indexList to get stats for all indices
 func (c *Client) IndexStatus(indexList []string) (*Response, error) {
+	return c.IndexStatusContext(context.Background(), indexList)
+}
+
+// IndexStatusContext is the context-aware version of IndexStatus.
+func (c *Client) IndexStatusContext(ctx context.Context, indexList []string) (*Response, error) {
 	r := Request{
 		IndexList: indexList,
 		Method:    "GET",
 		API:       "_status",
 	}
 
-	return c.Do(&r)
+	return c.DoContext(ctx, &r)
 }
 
 // BulkSend bulk adds multiple documents in bulk mode
 func (c *Client) BulkSend(documents []Document) (*Response, error) {
+	return c.BulkSendContext(context.Background(), documents)
+}
+
+// BulkSendContext is the context-aware version of BulkSend.
+func (c *Client) BulkSendContext(ctx context.Context, documents []Document) (*Response, error) {
+	var buf [][]byte
+	return c.bulkSendContextBuf(ctx, documents, &buf)
+}
+
+// bulkSendContextBuf is BulkSendContext, but reuses *buf's backing array
+// across calls instead of allocating a fresh [][]byte each time.
+func (c *Client) bulkSendContextBuf(ctx context.Context, documents []Document, buf *[][]byte) (*Response, error) {
+	resp, err := c.bulkSendRawBuf(ctx, documents, buf)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.Errors && c.retryPolicy != nil {
+		resp = c.retryBulkItems(ctx, documents, resp)
+	}
+
+	if resp.Errors {
+		for _, item := range resp.Items {
+			for _, i := range item {
+				if i.Error != "" {
+					return resp, &SearchError{i.Error, i.Status}
+				}
+			}
+		}
+		return resp, &SearchError{Msg: "Unknown error while bulk indexing"}
+	}
+
+	return resp, nil
+}
+
+// bulkSendRaw is bulkSendRawBuf with a one-off, not-reused backing buffer.
+func (c *Client) bulkSendRaw(ctx context.Context, documents []Document) (*Response, error) {
+	var buf [][]byte
+	return c.bulkSendRawBuf(ctx, documents, &buf)
+}
+
+// bulkSendRawBuf marshals documents into the Elasticsearch bulk format
+// and sends them, returning the raw aggregate response without
+// inspecting per-item errors. It reuses *buf's backing array across
+// calls rather than allocating a fresh [][]byte each time, growing it
+// when a batch needs more room.
+func (c *Client) bulkSendRawBuf(ctx context.Context, documents []Document, buf *[][]byte) (*Response, error) {
 	// We do not generate a traditional JSON here (often a one liner)
 	// Elasticsearch expects one line of JSON per line (EOL = \n)
 	// plus an extra \n at the very end of the document
@@ -149,7 +246,13 @@ func (c *Client) BulkSend(documents []Document) (*Response, error) {
 
 	// len(documents) * 2 : action + optional_sources
 	// + 1 : room for the trailing \n
-	bulkData := make([][]byte, len(documents)*2+1)
+	need := len(documents)*2 + 1
+	if cap(*buf) < need {
+		*buf = make([][]byte, need)
+	} else {
+		*buf = (*buf)[:need]
+	}
+	bulkData := *buf
 	i := 0
 
 	for _, doc := range documents {
@@ -196,8 +299,13 @@ func (c *Client) BulkSend(documents []Document) (*Response, error) {
 		}
 	}
 
-	// forces an extra trailing \n absolutely necessary for elasticsearch
-	bulkData[len(bulkData)-1] = []byte(nil)
+	// Clears everything from the last line we actually wrote onward: the
+	// trailing slot forces the \n elasticsearch requires at the end of
+	// the document, and the rest guards against stale entries left over
+	// from a previous, longer batch when bulkData came from a reused buf.
+	for j := i; j < len(bulkData); j++ {
+		bulkData[j] = nil
+	}
 
 	r := Request{
 		Method:   "POST",
@@ -205,27 +313,74 @@ func (c *Client) BulkSend(documents []Document) (*Response, error) {
 		BulkData: bytes.Join(bulkData, []byte("\n")),
 	}
 
-	resp, err := c.Do(&r)
-	if err != nil {
-		return resp, err
-	}
+	return c.DoContext(ctx, &r)
+}
 
-	if resp.Errors {
+// retryBulkItems re-issues a bulk request containing only the items of resp
+// that failed with a retryable status, waiting between rounds according to
+// c.retryPolicy, and merges the outcome back into resp so the caller still
+// sees a single aggregate *Response.
+func (c *Client) retryBulkItems(ctx context.Context, documents []Document, resp *Response) *Response {
+	policy := c.retryPolicy
+	var buf [][]byte
+
+	for attempt := 0; resp.Errors && attempt < policy.MaxAttempts-1; attempt++ {
+		var retryDocs []Document
+		var retryIdx []int
+
+		for idx, item := range resp.Items {
+			for _, i := range item {
+				if i.Error != "" && policy.isRetryableStatus(i.Status) {
+					retryDocs = append(retryDocs, documents[idx])
+					retryIdx = append(retryIdx, idx)
+				}
+			}
+		}
+
+		if len(retryDocs) == 0 {
+			return resp
+		}
+
+		select {
+		case <-ctx.Done():
+			return resp
+		case <-time.After(policy.backoff(attempt)):
+		}
+
+		retryResp, err := c.bulkSendRawBuf(ctx, retryDocs, &buf)
+		if err != nil {
+			return resp
+		}
+
+		for i, idx := range retryIdx {
+			if i < len(retryResp.Items) {
+				resp.Items[idx] = retryResp.Items[i]
+			}
+		}
+
+		// Recompute from the full item set, not just the items we just
+		// retried: a retryable item succeeding must not paper over an
+		// untouched, permanently-failed item elsewhere in resp.
+		resp.Errors = false
 		for _, item := range resp.Items {
 			for _, i := range item {
 				if i.Error != "" {
-					return resp, &SearchError{i.Error, i.Status}
+					resp.Errors = true
 				}
 			}
 		}
-		return resp, &SearchError{Msg: "Unknown error while bulk indexing"}
 	}
 
-	return resp, err
+	return resp
 }
 
 // Search executes a search query against an index
 func (c *Client) Search(query interface{}, indexList []string, typeList []string, extraArgs url.Values) (*Response, error) {
+	return c.SearchContext(context.Background(), query, indexList, typeList, extraArgs)
+}
+
+// SearchContext is the context-aware version of Search.
+func (c *Client) SearchContext(ctx context.Context, query interface{}, indexList []string, typeList []string, extraArgs url.Values) (*Response, error) {
 	r := Request{
 		Query:     query,
 		IndexList: indexList,
@@ -235,11 +390,16 @@ func (c *Client) Search(query interface{}, indexList []string, typeList []string
 		ExtraArgs: extraArgs,
 	}
 
-	return c.Do(&r)
+	return c.DoContext(ctx, &r)
 }
 
 // Count executes a count query against an index, use the Count field in the response for the result
 func (c *Client) Count(query interface{}, indexList []string, typeList []string, extraArgs url.Values) (*Response, error) {
+	return c.CountContext(context.Background(), query, indexList, typeList, extraArgs)
+}
+
+// CountContext is the context-aware version of Count.
+func (c *Client) CountContext(ctx context.Context, query interface{}, indexList []string, typeList []string, extraArgs url.Values) (*Response, error) {
 	r := Request{
 		Query:     query,
 		IndexList: indexList,
@@ -249,13 +409,18 @@ func (c *Client) Count(query interface{}, indexList []string, typeList []string,
 		ExtraArgs: extraArgs,
 	}
 
-	return c.Do(&r)
+	return c.DoContext(ctx, &r)
 }
 
 //Query runs a query against an index using the provided http method.
 //This method can be used to execute a delete by query, just pass in "DELETE"
 //for the HTTP method.
 func (c *Client) Query(query interface{}, indexList []string, typeList []string, httpMethod string, extraArgs url.Values) (*Response, error) {
+	return c.QueryContext(context.Background(), query, indexList, typeList, httpMethod, extraArgs)
+}
+
+// QueryContext is the context-aware version of Query.
+func (c *Client) QueryContext(ctx context.Context, query interface{}, indexList []string, typeList []string, httpMethod string, extraArgs url.Values) (*Response, error) {
 	r := Request{
 		Query:     query,
 		IndexList: indexList,
@@ -265,11 +430,16 @@ func (c *Client) Query(query interface{}, indexList []string, typeList []string,
 		ExtraArgs: extraArgs,
 	}
 
-	return c.Do(&r)
+	return c.DoContext(ctx, &r)
 }
 
 // Scan starts scroll over an index
 func (c *Client) Scan(query interface{}, indexList []string, typeList []string, timeout string, size int) (*Response, error) {
+	return c.ScanContext(context.Background(), query, indexList, typeList, timeout, size)
+}
+
+// ScanContext is the context-aware version of Scan.
+func (c *Client) ScanContext(ctx context.Context, query interface{}, indexList []string, typeList []string, timeout string, size int) (*Response, error) {
 	v := url.Values{}
 	v.Add("search_type", "scan")
 	v.Add("scroll", timeout)
@@ -284,11 +454,16 @@ func (c *Client) Scan(query interface{}, indexList []string, typeList []string,
 		ExtraArgs: v,
 	}
 
-	return c.Do(&r)
+	return c.DoContext(ctx, &r)
 }
 
 // Scroll fetches data by scroll id
 func (c *Client) Scroll(scrollID string, timeout string) (*Response, error) {
+	return c.ScrollContext(context.Background(), scrollID, timeout)
+}
+
+// ScrollContext is the context-aware version of Scroll.
+func (c *Client) ScrollContext(ctx context.Context, scrollID string, timeout string) (*Response, error) {
 	v := url.Values{}
 	v.Add("scroll", timeout)
 
@@ -299,11 +474,16 @@ func (c *Client) Scroll(scrollID string, timeout string) (*Response, error) {
 		Body:      []byte(scrollID),
 	}
 
-	return c.Do(&r)
+	return c.DoContext(ctx, &r)
 }
 
 // Get a typed document by its id
 func (c *Client) Get(index string, documentType string, id string, extraArgs url.Values) (*Response, error) {
+	return c.GetContext(context.Background(), index, documentType, id, extraArgs)
+}
+
+// GetContext is the context-aware version of Get.
+func (c *Client) GetContext(ctx context.Context, index string, documentType string, id string, extraArgs url.Values) (*Response, error) {
 	r := Request{
 		IndexList: []string{index},
 		Method:    "GET",
@@ -311,13 +491,18 @@ func (c *Client) Get(index string, documentType string, id string, extraArgs url
 		ExtraArgs: extraArgs,
 	}
 
-	return c.Do(&r)
+	return c.DoContext(ctx, &r)
 }
 
 // Index indexes a Document
 // The extraArgs is a list of url.Values that you can send to elasticsearch as
 // URL arguments, for example, to control routing, ttl, version, op_type, etc.
 func (c *Client) Index(d Document, extraArgs url.Values) (*Response, error) {
+	return c.IndexContext(context.Background(), d, extraArgs)
+}
+
+// IndexContext is the context-aware version of Index.
+func (c *Client) IndexContext(ctx context.Context, d Document, extraArgs url.Values) (*Response, error) {
 	r := Request{
 		Query:     d.Fields,
 		IndexList: []string{d.Index.(string)},
@@ -331,13 +516,18 @@ func (c *Client) Index(d Document, extraArgs url.Values) (*Response, error) {
 		r.ID = d.ID.(string)
 	}
 
-	return c.Do(&r)
+	return c.DoContext(ctx, &r)
 }
 
 // Delete deletes a Document d
 // The extraArgs is a list of url.Values that you can send to elasticsearch as
 // URL arguments, for example, to control routing.
 func (c *Client) Delete(d Document, extraArgs url.Values) (*Response, error) {
+	return c.DeleteContext(context.Background(), d, extraArgs)
+}
+
+// DeleteContext is the context-aware version of Delete.
+func (c *Client) DeleteContext(ctx context.Context, d Document, extraArgs url.Values) (*Response, error) {
 	r := Request{
 		IndexList: []string{d.Index.(string)},
 		TypeList:  []string{d.Type},
@@ -346,7 +536,7 @@ func (c *Client) Delete(d Document, extraArgs url.Values) (*Response, error) {
 		ID:        d.ID.(string),
 	}
 
-	return c.Do(&r)
+	return c.DoContext(ctx, &r)
 }
 
 // Buckets returns list of buckets in aggregation
@@ -381,7 +571,11 @@ func (b Bucket) Aggregation(name string) Aggregation {
 
 // PutMapping registers a specific mapping for one or more types in one or more indexes
 func (c *Client) PutMapping(typeName string, mapping interface{}, indexes []string) (*Response, error) {
+	return c.PutMappingContext(context.Background(), typeName, mapping, indexes)
+}
 
+// PutMappingContext is the context-aware version of PutMapping.
+func (c *Client) PutMappingContext(ctx context.Context, typeName string, mapping interface{}, indexes []string) (*Response, error) {
 	r := Request{
 		Query:     mapping,
 		IndexList: indexes,
@@ -389,36 +583,49 @@ func (c *Client) PutMapping(typeName string, mapping interface{}, indexes []stri
 		API:       "_mappings/" + typeName,
 	}
 
-	return c.Do(&r)
+	return c.DoContext(ctx, &r)
 }
 
 // GetMapping returns the mappings for the specified types
 func (c *Client) GetMapping(types []string, indexes []string) (*Response, error) {
+	return c.GetMappingContext(context.Background(), types, indexes)
+}
 
+// GetMappingContext is the context-aware version of GetMapping.
+func (c *Client) GetMappingContext(ctx context.Context, types []string, indexes []string) (*Response, error) {
 	r := Request{
 		IndexList: indexes,
 		Method:    "GET",
 		API:       "_mapping/" + strings.Join(types, ","),
 	}
 
-	return c.Do(&r)
+	return c.DoContext(ctx, &r)
 }
 
 // IndicesExist checks whether index (or indices) exist on the server
 func (c *Client) IndicesExist(indexes []string) (bool, error) {
+	return c.IndicesExistContext(context.Background(), indexes)
+}
 
+// IndicesExistContext is the context-aware version of IndicesExist.
+func (c *Client) IndicesExistContext(ctx context.Context, indexes []string) (bool, error) {
 	r := Request{
 		IndexList: indexes,
 		Method:    "HEAD",
 	}
 
-	resp, err := c.Do(&r)
+	resp, err := c.DoContext(ctx, &r)
 
 	return resp.Status == 200, err
 }
 
 // Update updates the specified document using the _update endpoint
 func (c *Client) Update(d Document, query interface{}, extraArgs url.Values) (*Response, error) {
+	return c.UpdateContext(context.Background(), d, query, extraArgs)
+}
+
+// UpdateContext is the context-aware version of Update.
+func (c *Client) UpdateContext(ctx context.Context, d Document, query interface{}, extraArgs url.Values) (*Response, error) {
 	r := Request{
 		Query:     query,
 		IndexList: []string{d.Index.(string)},
@@ -432,22 +639,30 @@ func (c *Client) Update(d Document, query interface{}, extraArgs url.Values) (*R
 		r.ID = d.ID.(string)
 	}
 
-	return c.Do(&r)
+	return c.DoContext(ctx, &r)
 }
 
 // DeleteMapping deletes a mapping along with all data in the type
 func (c *Client) DeleteMapping(typeName string, indexes []string) (*Response, error) {
+	return c.DeleteMappingContext(context.Background(), typeName, indexes)
+}
 
+// DeleteMappingContext is the context-aware version of DeleteMapping.
+func (c *Client) DeleteMappingContext(ctx context.Context, typeName string, indexes []string) (*Response, error) {
 	r := Request{
 		IndexList: indexes,
 		Method:    "DELETE",
 		API:       "_mappings/" + typeName,
 	}
 
-	return c.Do(&r)
+	return c.DoContext(ctx, &r)
 }
 
 func (c *Client) modifyAlias(action string, alias string, indexes []string) (*Response, error) {
+	return c.modifyAliasContext(context.Background(), action, alias, indexes)
+}
+
+func (c *Client) modifyAliasContext(ctx context.Context, action string, alias string, indexes []string) (*Response, error) {
 	command := map[string]interface{}{
 		"actions": make([]map[string]interface{}, 1),
 	}
@@ -467,7 +682,7 @@ func (c *Client) modifyAlias(action string, alias string, indexes []string) (*Re
 		API:    "_aliases",
 	}
 
-	return c.Do(&r)
+	return c.DoContext(ctx, &r)
 }
 
 // AddAlias creates an alias to one or more indexes
@@ -475,38 +690,76 @@ func (c *Client) AddAlias(alias string, indexes []string) (*Response, error) {
 	return c.modifyAlias("add", alias, indexes)
 }
 
+// AddAliasContext is the context-aware version of AddAlias.
+func (c *Client) AddAliasContext(ctx context.Context, alias string, indexes []string) (*Response, error) {
+	return c.modifyAliasContext(ctx, "add", alias, indexes)
+}
+
 // RemoveAlias removes an alias to one or more indexes
 func (c *Client) RemoveAlias(alias string, indexes []string) (*Response, error) {
 	return c.modifyAlias("remove", alias, indexes)
 }
 
+// RemoveAliasContext is the context-aware version of RemoveAlias.
+func (c *Client) RemoveAliasContext(ctx context.Context, alias string, indexes []string) (*Response, error) {
+	return c.modifyAliasContext(ctx, "remove", alias, indexes)
+}
+
 // AliasExists checks whether alias is defined on the server
 func (c *Client) AliasExists(alias string) (bool, error) {
+	return c.AliasExistsContext(context.Background(), alias)
+}
 
+// AliasExistsContext is the context-aware version of AliasExists.
+func (c *Client) AliasExistsContext(ctx context.Context, alias string) (bool, error) {
 	r := Request{
 		Method: "HEAD",
 		API:    "_alias/" + alias,
 	}
 
-	resp, err := c.Do(&r)
+	resp, err := c.DoContext(ctx, &r)
 
 	return resp.Status == 200, err
 }
 
 // Do runs the request returned by the requestor and returns the parsed response
 func (c *Client) Do(r Requester) (*Response, error) {
+	return c.DoContext(context.Background(), r)
+}
+
+// DoContext runs the request returned by the requestor with the given context and
+// returns the parsed response. The context is attached to the outgoing HTTP request
+// via http.Request.WithContext, so cancellation, deadlines, and tracing values
+// flow through to the underlying transport.
+func (c *Client) DoContext(ctx context.Context, r Requester) (*Response, error) {
 	req, err := r.Request()
 	if err != nil {
 		return &Response{}, err
 	}
-	if c.IsHTTPS {
-		req.URL.Scheme = "https"
+	req = req.WithContext(ctx)
+
+	var body []byte
+	var statusCode uint64
+
+	if c.transport != nil {
+		body, statusCode, err = c.doRequestWithTransport(req)
 	} else {
-		req.URL.Scheme = "http"
+		if c.IsHTTPS {
+			req.URL.Scheme = "https"
+		} else {
+			req.URL.Scheme = "http"
+		}
+		req.URL.Host = fmt.Sprintf("%s:%s", c.Host, c.Port)
+
+		if c.signer != nil {
+			if err := c.signer.SignRequest(req); err != nil {
+				return &Response{}, fmt.Errorf("goes: signing request: %w", err)
+			}
+		}
+
+		body, statusCode, err = c.doRequestWithRetry(req)
 	}
-	req.URL.Host = fmt.Sprintf("%s:%s", c.Host, c.Port)
 
-	body, statusCode, err := c.doRequest(req)
 	esResp := &Response{Status: statusCode}
 
 	if err != nil {
@@ -538,6 +791,54 @@ func (c *Client) Do(r Requester) (*Response, error) {
 	return esResp, nil
 }
 
+// doRequestWithRetry runs req through doRequest, retrying according to
+// c.retryPolicy on network errors and retryable status codes. With no retry
+// policy configured it behaves exactly like doRequest did before retries
+// existed.
+func (c *Client) doRequestWithRetry(req *http.Request) ([]byte, uint64, error) {
+	policy := c.retryPolicy
+	if policy == nil {
+		return c.doRequest(req)
+	}
+
+	var bodyBytes []byte
+	if req.Body != nil {
+		bodyBytes, _ = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+	}
+
+	var (
+		body       []byte
+		statusCode uint64
+		err        error
+	)
+
+	attempts := policy.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	for attempt := 0; attempt < attempts; attempt++ {
+		if bodyBytes != nil {
+			req.Body = ioutil.NopCloser(bytes.NewReader(bodyBytes))
+			req.ContentLength = int64(len(bodyBytes))
+		}
+
+		body, statusCode, err = c.doRequest(req)
+		if !policy.shouldRetry(statusCode, err) || attempt == attempts-1 {
+			return body, statusCode, err
+		}
+
+		select {
+		case <-req.Context().Done():
+			return body, statusCode, req.Context().Err()
+		case <-time.After(policy.backoff(attempt)):
+		}
+	}
+
+	return body, statusCode, err
+}
+
 func (c *Client) doRequest(req *http.Request) ([]byte, uint64, error) {
 	resp, err := c.Client.Do(req)
 	if err != nil {
@@ -0,0 +1,69 @@
+package goes
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestBulkSendContextRetryKeepsPermanentError exercises the scenario the
+// maintainer flagged: a bulk response mixing a retryable item (429) with
+// a permanent one (400). Once the retryable item succeeds on retry,
+// resp.Errors must stay true and BulkSendContext must still return an
+// error, because the permanent item was never fixed.
+func TestBulkSendContextRetryKeepsPermanentError(t *testing.T) {
+	var calls int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		if calls == 1 {
+			fmt.Fprint(w, `{
+				"errors": true,
+				"items": [
+					{"index": {"status": 429, "error": "too many requests"}},
+					{"index": {"status": 400, "error": "mapping error"}}
+				]
+			}`)
+			return
+		}
+		fmt.Fprint(w, `{
+			"errors": false,
+			"items": [
+				{"index": {"status": 201}}
+			]
+		}`)
+	}))
+	defer server.Close()
+
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("SplitHostPort: %v", err)
+	}
+
+	c := &Client{
+		Host:        host,
+		Port:        port,
+		Client:      http.DefaultClient,
+		retryPolicy: DefaultRetryPolicy(),
+	}
+
+	docs := []Document{
+		{BulkCommand: BulkCommandIndex, Index: "i", Type: "t", ID: "1"},
+		{BulkCommand: BulkCommandIndex, Index: "i", Type: "t", ID: "2"},
+	}
+
+	resp, err := c.BulkSendContext(context.Background(), docs)
+	if err == nil {
+		t.Fatal("BulkSendContext returned nil error, want an error for the untouched permanent failure")
+	}
+	if !resp.Errors {
+		t.Fatal("resp.Errors = false after retry, want true: the permanent item's error must not be papered over")
+	}
+	if calls != 2 {
+		t.Fatalf("server got %d calls, want 2 (initial send + one retry round)", calls)
+	}
+}
@@ -0,0 +1,78 @@
+package goes
+
+import (
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy controls how a Client retries failed requests: how many
+// times to attempt a request, how long to wait between attempts, and
+// which failures are worth retrying at all.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of tries, including the first one.
+	// A value <= 1 disables retries.
+	MaxAttempts int
+	// BaseDelay is the wait before the first retry; it doubles on each
+	// subsequent attempt until it reaches MaxDelay.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff delay regardless of attempt count.
+	MaxDelay time.Duration
+	// Jitter is the fraction (0-1) of the computed delay added at
+	// random, to avoid retry storms across many clients.
+	Jitter float64
+	// RetryableStatus lists HTTP status codes that are safe to retry.
+	// Network errors (a nil status) are always retried.
+	RetryableStatus map[uint64]bool
+}
+
+// DefaultRetryPolicy retries network errors and 429/502/503/504 responses,
+// making up to 3 attempts in all, backing off from 100ms to 2s with 20%
+// jitter.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   100 * time.Millisecond,
+		MaxDelay:    2 * time.Second,
+		Jitter:      0.2,
+		RetryableStatus: map[uint64]bool{
+			429: true,
+			502: true,
+			503: true,
+			504: true,
+		},
+	}
+}
+
+// WithRetryPolicy sets the retry policy used by Do, DoContext, and
+// BulkSend's per-item retry. Passing nil disables retries. Returns the
+// original client so it can be chained with the other With* builders.
+func (c *Client) WithRetryPolicy(p *RetryPolicy) *Client {
+	c.retryPolicy = p
+	return c
+}
+
+func (p *RetryPolicy) isRetryableStatus(status uint64) bool {
+	return p.RetryableStatus[status]
+}
+
+// shouldRetry reports whether a request that failed with err (possibly
+// nil) and the given status code is worth retrying under this policy.
+func (p *RetryPolicy) shouldRetry(status uint64, err error) bool {
+	if err != nil {
+		return true
+	}
+	return p.isRetryableStatus(status)
+}
+
+// backoff returns how long to wait before the retry following the given
+// zero-based attempt number.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay << uint(attempt)
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if p.Jitter > 0 {
+		delay += time.Duration(rand.Float64() * p.Jitter * float64(delay))
+	}
+	return delay
+}
@@ -0,0 +1,179 @@
+package goes
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// ScrollIterator walks the full result set of a scan/scroll query one
+// page at a time via Next, and releases the scroll context via Close.
+type ScrollIterator struct {
+	client *Client
+
+	query     interface{}
+	indexList []string
+	typeList  []string
+	timeout   string
+	size      int
+
+	scrollID string
+	started  bool
+	done     bool
+}
+
+// NewScrollIterator returns an iterator that scans query over
+// indexList/typeList in pages of size, keeping the scroll context alive
+// for timeout between calls to Next. The initial scan is issued lazily,
+// on the first call to Next, so construction cannot itself fail.
+func (c *Client) NewScrollIterator(query interface{}, indexList []string, typeList []string, timeout string, size int) *ScrollIterator {
+	return &ScrollIterator{
+		client:    c,
+		query:     query,
+		indexList: indexList,
+		typeList:  typeList,
+		timeout:   timeout,
+		size:      size,
+	}
+}
+
+// Next fetches the next page of results. It returns (resp, true, nil) as
+// long as the scroll has more hits, and (nil, false, nil) once it is
+// exhausted. Callers should call Close when done, whether or not the
+// scroll was fully consumed, to release the scroll context server-side.
+func (s *ScrollIterator) Next(ctx context.Context) (*Response, bool, error) {
+	if s.done {
+		return nil, false, nil
+	}
+
+	var (
+		resp *Response
+		err  error
+	)
+
+	if !s.started {
+		s.started = true
+		resp, err = s.client.ScanContext(ctx, s.query, s.indexList, s.typeList, s.timeout, s.size)
+	} else {
+		resp, err = s.client.ScrollContext(ctx, s.scrollID, s.timeout)
+	}
+
+	if err != nil {
+		s.done = true
+		return nil, false, err
+	}
+
+	s.scrollID = resp.ScrollID
+
+	if len(resp.Hits.Hits) == 0 {
+		s.done = true
+		return nil, false, nil
+	}
+
+	return resp, true, nil
+}
+
+// Close releases the scroll context on the server via DELETE
+// _search/scroll. It is safe to call more than once, or after the scan
+// was never started.
+func (s *ScrollIterator) Close(ctx context.Context) error {
+	if s.scrollID == "" {
+		return nil
+	}
+
+	r := Request{
+		Method: "DELETE",
+		API:    "_search/scroll",
+		Body:   []byte(s.scrollID),
+	}
+
+	_, err := s.client.DoContext(ctx, &r)
+	s.scrollID = ""
+	return err
+}
+
+// SearchAfterIterator paginates deeply into a result set using
+// search_after instead of a server-side scroll context. It requires
+// query's "sort" clause to produce a unique tie-break (commonly a field
+// plus "_id", or ["_doc"]). Prefer it over ScrollIterator for long-lived
+// or concurrent pagination, since scroll contexts are being deprecated
+// in newer Elasticsearch versions.
+type SearchAfterIterator struct {
+	client *Client
+
+	query     map[string]interface{}
+	indexList []string
+	typeList  []string
+	extraArgs url.Values
+	size      int
+
+	searchAfter []interface{}
+	started     bool
+	done        bool
+}
+
+// NewSearchAfterIterator returns a SearchAfterIterator over query, which
+// must already contain a "sort" clause. query is copied per page rather
+// than mutated in place, so the caller's map can be reused elsewhere.
+func (c *Client) NewSearchAfterIterator(query map[string]interface{}, indexList []string, typeList []string, size int, extraArgs url.Values) *SearchAfterIterator {
+	return &SearchAfterIterator{
+		client:    c,
+		query:     query,
+		indexList: indexList,
+		typeList:  typeList,
+		extraArgs: extraArgs,
+		size:      size,
+	}
+}
+
+// Next fetches the next page of results, returning (resp, true, nil)
+// while pages remain and (nil, false, nil) once a page comes back empty.
+func (s *SearchAfterIterator) Next(ctx context.Context) (*Response, bool, error) {
+	if s.done {
+		return nil, false, nil
+	}
+	s.started = true
+
+	page := make(map[string]interface{}, len(s.query)+2)
+	for k, v := range s.query {
+		page[k] = v
+	}
+	page["size"] = s.size
+	if s.searchAfter != nil {
+		page["search_after"] = s.searchAfter
+	}
+
+	resp, err := s.client.SearchContext(ctx, page, s.indexList, s.typeList, s.extraArgs)
+	if err != nil {
+		s.done = true
+		return nil, false, err
+	}
+
+	hits := resp.Hits.Hits
+	if len(hits) == 0 {
+		s.done = true
+		return nil, false, nil
+	}
+
+	last, ok := hits[len(hits)-1].(map[string]interface{})
+	if !ok {
+		s.done = true
+		return nil, false, fmt.Errorf("goes: search_after: hit has an unexpected shape, cannot extract sort tie-break")
+	}
+
+	sort, ok := last["sort"].([]interface{})
+	if !ok {
+		s.done = true
+		return nil, false, fmt.Errorf("goes: search_after: query must contain a sort clause, last hit has no usable \"sort\" values")
+	}
+	s.searchAfter = sort
+
+	return resp, true, nil
+}
+
+// Close is a no-op: search_after keeps no server-side context to release.
+// It exists so SearchAfterIterator can be used interchangeably with
+// ScrollIterator behind a common Next/Close pattern.
+func (s *SearchAfterIterator) Close(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,44 @@
+package goes
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestSearchAfterIteratorNextErrorsWithoutSort covers the precondition
+// the type's doc comment calls out: if query has no sort clause, a hit
+// carries no "sort" values to page from. Next must report that instead
+// of silently repeating the same page forever.
+func TestSearchAfterIteratorNextErrorsWithoutSort(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"hits": {"hits": [{"_id": "1"}]}}`)
+	}))
+	defer server.Close()
+
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("SplitHostPort: %v", err)
+	}
+
+	c := &Client{Host: host, Port: port, Client: http.DefaultClient}
+	it := c.NewSearchAfterIterator(map[string]interface{}{}, []string{"i"}, nil, 10, nil)
+
+	_, more, err := it.Next(context.Background())
+	if err == nil {
+		t.Fatal("Next returned nil error for a hit with no sort values, want an error")
+	}
+	if more {
+		t.Fatal("Next returned more=true alongside an error")
+	}
+
+	// A second call must not retry the same request forever; the
+	// iterator is done once Next has failed.
+	if _, more, err := it.Next(context.Background()); err != nil || more {
+		t.Fatalf("Next after failure = (more=%v, err=%v), want (false, nil)", more, err)
+	}
+}
@@ -0,0 +1,376 @@
+package goes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Node is a single Elasticsearch endpoint known to a Client's transport,
+// along with the health state used to decide whether it is safe to send
+// it a request.
+type Node struct {
+	// URL is the scheme+host (e.g. "http://10.0.0.1:9200") requests are
+	// sent to when this node is selected.
+	URL *url.URL
+
+	mu        sync.Mutex
+	deadUntil time.Time
+	failures  int
+}
+
+// alive reports whether now is past this node's cool-down window.
+func (n *Node) alive(now time.Time) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return !now.Before(n.deadUntil)
+}
+
+// deadline returns the time this node's cool-down window ends.
+func (n *Node) deadline() time.Time {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.deadUntil
+}
+
+// markDead pulls the node out of rotation for a cool-down window that
+// doubles with each consecutive failure, capped at a minute.
+func (n *Node) markDead() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.failures++
+	cooldown := time.Duration(1<<uint(n.failures-1)) * time.Second
+	if cooldown <= 0 || cooldown > time.Minute {
+		cooldown = time.Minute
+	}
+	n.deadUntil = time.Now().Add(cooldown)
+}
+
+// markAlive clears the node's failure count after a successful request.
+func (n *Node) markAlive() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.failures = 0
+	n.deadUntil = time.Time{}
+}
+
+// NodeSelector picks which of the currently live nodes a request should
+// be sent to next. Implementations must be safe for concurrent use.
+type NodeSelector interface {
+	// Select returns the index into nodes to use. nodes contains only
+	// nodes currently considered live and is never empty.
+	Select(nodes []*Node) int
+}
+
+// roundRobinSelector is the default NodeSelector: it cycles through the
+// live nodes in order.
+type roundRobinSelector struct {
+	mu   sync.Mutex
+	next uint64
+}
+
+func (s *roundRobinSelector) Select(nodes []*Node) int {
+	s.mu.Lock()
+	idx := s.next % uint64(len(nodes))
+	s.next++
+	s.mu.Unlock()
+	return int(idx)
+}
+
+// transport holds the pool of node endpoints a Client created with
+// NewClientFromURLs sends requests through. It round-robins across live
+// nodes, pulls failing nodes out of rotation, and optionally keeps the
+// pool in sync with cluster membership via periodic sniffing.
+type transport struct {
+	mu    sync.RWMutex
+	nodes []*Node
+
+	selector      NodeSelector
+	sniffInterval time.Duration
+	stopSniff     chan struct{}
+}
+
+// newTransport builds a transport from a seed list of node URLs.
+func newTransport(urls []string) (*transport, error) {
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("goes: NewClientFromURLs requires at least one URL")
+	}
+
+	nodes, err := parseNodeURLs(urls)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transport{nodes: nodes, selector: &roundRobinSelector{}}, nil
+}
+
+func parseNodeURLs(urls []string) ([]*Node, error) {
+	nodes := make([]*Node, 0, len(urls))
+	for _, raw := range urls {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("goes: parsing node URL %q: %w", raw, err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("goes: node URL %q must include a scheme and host", raw)
+		}
+		nodes = append(nodes, &Node{URL: u})
+	}
+	return nodes, nil
+}
+
+// nodeCount returns the current pool size.
+func (t *transport) nodeCount() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.nodes)
+}
+
+// pick returns a node to send the next request to, preferring live nodes
+// and falling back to the one closest to recovering if every node in the
+// pool is currently cooling down.
+func (t *transport) pick() *Node {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	now := time.Now()
+	live := make([]*Node, 0, len(t.nodes))
+	for _, n := range t.nodes {
+		if n.alive(now) {
+			live = append(live, n)
+		}
+	}
+
+	if len(live) == 0 {
+		best := t.nodes[0]
+		bestDeadline := best.deadline()
+		for _, n := range t.nodes[1:] {
+			if d := n.deadline(); d.Before(bestDeadline) {
+				best = n
+				bestDeadline = d
+			}
+		}
+		return best
+	}
+
+	return live[t.selector.Select(live)]
+}
+
+// replace swaps in a freshly sniffed node list, discarding the health
+// state of the old one.
+func (t *transport) replace(nodes []*Node) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.nodes = nodes
+}
+
+// startSniffing launches the background goroutine that refreshes the
+// node pool every sniffInterval until stopSniffing is called.
+func (t *transport) startSniffing(httpClient *http.Client) {
+	t.stopSniff = make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(t.sniffInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				_ = t.sniff(context.Background(), httpClient)
+			case <-t.stopSniff:
+				return
+			}
+		}
+	}()
+}
+
+// stopSniffing stops the background sniffing goroutine, if running.
+func (t *transport) stopSniffing() {
+	if t.stopSniff != nil {
+		close(t.stopSniff)
+		t.stopSniff = nil
+	}
+}
+
+// nodesHTTPResponse is the subset of the _nodes/http response we need to
+// rebuild the pool.
+type nodesHTTPResponse struct {
+	Nodes map[string]struct {
+		HTTP struct {
+			PublishAddress string `json:"publish_address"`
+		} `json:"http"`
+	} `json:"nodes"`
+}
+
+// sniff issues GET _nodes/http against a live node and replaces the pool
+// with the addresses it reports, reusing that node's scheme since the
+// API does not return one.
+func (t *transport) sniff(ctx context.Context, httpClient *http.Client) error {
+	n := t.pick()
+
+	u := *n.URL
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/_nodes/http"
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		n.markDead()
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var parsed nodesHTTPResponse
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return err
+	}
+
+	urls := make([]string, 0, len(parsed.Nodes))
+	for _, info := range parsed.Nodes {
+		addr := info.HTTP.PublishAddress
+		if i := strings.LastIndex(addr, "/"); i >= 0 {
+			// publish_address is sometimes "hostname/ip:port".
+			addr = addr[i+1:]
+		}
+		if addr == "" {
+			continue
+		}
+		urls = append(urls, n.URL.Scheme+"://"+addr)
+	}
+
+	if len(urls) == 0 {
+		return fmt.Errorf("goes: sniffing: _nodes/http reported no nodes")
+	}
+
+	nodes, err := parseNodeURLs(urls)
+	if err != nil {
+		return err
+	}
+
+	t.replace(nodes)
+	return nil
+}
+
+// Option configures a Client created by NewClientFromURLs.
+type Option func(*Client)
+
+// WithSniffing enables periodic node discovery: every interval, the
+// transport calls GET _nodes/http against a live node and replaces its
+// pool with the addresses it reports. The default, a zero interval,
+// disables sniffing, so the pool never grows beyond the URLs passed to
+// NewClientFromURLs.
+func WithSniffing(interval time.Duration) Option {
+	return func(c *Client) {
+		c.transport.sniffInterval = interval
+	}
+}
+
+// WithNodeSelector replaces the strategy used to choose among live nodes
+// for each request. The default is round-robin.
+func WithNodeSelector(s NodeSelector) Option {
+	return func(c *Client) {
+		c.transport.selector = s
+	}
+}
+
+// NewClientFromURLs creates a Client backed by a pool of node endpoints
+// instead of the single host/port NewClient takes. Requests round-robin
+// across the live nodes; a node that errors or returns a 5xx is pulled
+// out of rotation for a growing cool-down window and the request is
+// retried against another node. Pass WithSniffing to keep the pool in
+// sync with cluster membership, and WithNodeSelector to replace the
+// default round-robin strategy.
+func NewClientFromURLs(urls []string, opts ...Option) (*Client, error) {
+	t, err := newTransport(urls)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{Client: http.DefaultClient, transport: t}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if t.sniffInterval > 0 {
+		t.startSniffing(c.Client)
+	}
+
+	return c, nil
+}
+
+// Close stops the background sniffing goroutine started by WithSniffing,
+// if any. Clients not created with WithSniffing need not call it.
+func (c *Client) Close() error {
+	if c.transport != nil {
+		c.transport.stopSniffing()
+	}
+	return nil
+}
+
+// doRequestWithTransport runs req against the transport's node pool,
+// failing a node out of rotation on connection errors or 5xx responses
+// and retrying against another live node before giving up. Each node
+// attempt still goes through doRequestWithRetry, so the client's retry
+// policy (if any) applies within a single node before failover moves on.
+func (c *Client) doRequestWithTransport(req *http.Request) ([]byte, uint64, error) {
+	var bodyBytes []byte
+	if req.Body != nil {
+		bodyBytes, _ = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+	}
+
+	var (
+		body       []byte
+		statusCode uint64
+		err        error
+	)
+
+	for attempt, n := 0, c.transport.nodeCount(); attempt < n; attempt++ {
+		node := c.transport.pick()
+
+		req.URL.Scheme = node.URL.Scheme
+		req.URL.Host = node.URL.Host
+
+		if bodyBytes != nil {
+			req.Body = ioutil.NopCloser(bytes.NewReader(bodyBytes))
+			req.ContentLength = int64(len(bodyBytes))
+		}
+
+		if c.signer != nil {
+			if serr := c.signer.SignRequest(req); serr != nil {
+				return nil, 0, fmt.Errorf("goes: signing request: %w", serr)
+			}
+		}
+
+		body, statusCode, err = c.doRequestWithRetry(req)
+		if err == nil && statusCode < 500 {
+			node.markAlive()
+			return body, statusCode, nil
+		}
+
+		node.markDead()
+
+		select {
+		case <-req.Context().Done():
+			return body, statusCode, req.Context().Err()
+		default:
+		}
+	}
+
+	return body, statusCode, err
+}
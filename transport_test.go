@@ -0,0 +1,76 @@
+package goes
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestTransportPickMarkDeadRace exercises pick's all-dead fallback
+// concurrently with markDead/markAlive. Run with -race: before deadline
+// went through a locked accessor, this tripped the race detector on
+// Node.deadUntil.
+func TestTransportPickMarkDeadRace(t *testing.T) {
+	tr, err := newTransport([]string{
+		"http://node-a:9200",
+		"http://node-b:9200",
+		"http://node-c:9200",
+	})
+	if err != nil {
+		t.Fatalf("newTransport: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				n := tr.pick()
+				if n == nil {
+					t.Error("pick returned nil")
+					return
+				}
+				if (i+j)%2 == 0 {
+					n.markDead()
+				} else {
+					n.markAlive()
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestNewTransportRejectsEmptyURLs(t *testing.T) {
+	if _, err := newTransport(nil); err == nil {
+		t.Fatal("newTransport(nil) = nil error, want error")
+	}
+}
+
+func TestNewTransportRejectsInvalidURL(t *testing.T) {
+	if _, err := newTransport([]string{"not-a-url"}); err == nil {
+		t.Fatal("newTransport with schemeless URL = nil error, want error")
+	}
+}
+
+func TestTransportPickSkipsDeadNodes(t *testing.T) {
+	tr, err := newTransport([]string{"http://node-a:9200", "http://node-b:9200"})
+	if err != nil {
+		t.Fatalf("newTransport: %v", err)
+	}
+
+	var dead *Node
+	for _, n := range tr.nodes {
+		if n.URL.Host == "node-a:9200" {
+			dead = n
+		}
+	}
+	dead.markDead()
+
+	for i := 0; i < 10; i++ {
+		n := tr.pick()
+		if n.URL.Host != "node-b:9200" {
+			t.Fatalf("pick() = %v, want node-b while node-a is dead", n.URL)
+		}
+	}
+}
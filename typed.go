@@ -0,0 +1,128 @@
+package goes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// TypedResponse wraps a search response whose hits have already been
+// decoded into Hits.
+type TypedResponse[T any] struct {
+	// Response is the underlying untyped response, kept around for
+	// access to aggregations, status, and the raw payload.
+	Response *Response
+	Hits     []T
+	Total    int64
+}
+
+// SearchTyped runs a search like Client.Search, but decodes
+// hits.hits._source into []T instead of leaving that to the caller. The
+// untyped Search/SearchContext remain available for callers that don't
+// have (or want) a Go type for their documents.
+func SearchTyped[T any](ctx context.Context, c *Client, query interface{}, indexList []string, typeList []string, extraArgs url.Values) (*TypedResponse[T], error) {
+	resp, err := c.SearchContext(ctx, query, indexList, typeList, extraArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	hits, err := decodeHits[T](resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TypedResponse[T]{
+		Response: resp,
+		Hits:     hits,
+		Total:    resp.Hits.Total,
+	}, nil
+}
+
+// decodeHits extracts hits.hits._source from resp.Raw and unmarshals each
+// one into a T, returning an error on malformed input rather than the
+// panics the untyped Aggregation/Bucket accessors are prone to.
+func decodeHits[T any](resp *Response) ([]T, error) {
+	var envelope struct {
+		Hits struct {
+			Hits []struct {
+				Source json.RawMessage `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+
+	if err := json.Unmarshal(resp.Raw, &envelope); err != nil {
+		return nil, fmt.Errorf("goes: decoding typed hits: %w", err)
+	}
+
+	hits := make([]T, 0, len(envelope.Hits.Hits))
+	for _, h := range envelope.Hits.Hits {
+		var v T
+		if err := json.Unmarshal(h.Source, &v); err != nil {
+			return nil, fmt.Errorf("goes: decoding typed hit: %w", err)
+		}
+		hits = append(hits, v)
+	}
+
+	return hits, nil
+}
+
+// BucketsSafe is like Buckets, but returns ok=false when "buckets" is
+// absent and an error when it is present but not a list of objects,
+// instead of panicking on malformed input.
+func (a Aggregation) BucketsSafe() (buckets []Bucket, ok bool, err error) {
+	raw, present := a["buckets"]
+	if !present {
+		return nil, false, nil
+	}
+
+	list, isList := raw.([]interface{})
+	if !isList {
+		return nil, true, fmt.Errorf("goes: aggregation \"buckets\" is %T, not a list", raw)
+	}
+
+	buckets = make([]Bucket, 0, len(list))
+	for _, b := range list {
+		m, isMap := b.(map[string]interface{})
+		if !isMap {
+			return nil, true, fmt.Errorf("goes: aggregation bucket is %T, not an object", b)
+		}
+		buckets = append(buckets, m)
+	}
+
+	return buckets, true, nil
+}
+
+// DocCountSafe is like DocCount, but returns ok=false when "doc_count"
+// is absent and an error when it is present but not numeric, instead of
+// panicking on malformed input.
+func (b Bucket) DocCountSafe() (count uint64, ok bool, err error) {
+	raw, present := b["doc_count"]
+	if !present {
+		return 0, false, nil
+	}
+
+	f, isFloat := raw.(float64)
+	if !isFloat {
+		return 0, true, fmt.Errorf("goes: bucket \"doc_count\" is %T, not a number", raw)
+	}
+
+	return uint64(f), true, nil
+}
+
+// AggregationSafe is like Aggregation, but returns ok=false when name is
+// absent and an error when it is present but not an object, instead of
+// panicking on malformed input.
+func (b Bucket) AggregationSafe(name string) (agg Aggregation, ok bool, err error) {
+	raw, present := b[name]
+	if !present {
+		return nil, false, nil
+	}
+
+	m, isMap := raw.(map[string]interface{})
+	if !isMap {
+		return nil, true, fmt.Errorf("goes: bucket %q is %T, not an object", name, raw)
+	}
+
+	return m, true, nil
+}